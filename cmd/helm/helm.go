@@ -107,7 +107,13 @@ func checkOCIFeatureGate() func(_ *cobra.Command, _ []string) error {
 // This function loads releases into the memory storage if the
 // environment variable is properly set.
 func loadReleasesInMemory(actionConfig *action.Configuration) {
-	filePaths := strings.Split(os.Getenv("HELM_MEMORY_DRIVER_DATA"), ":")
+	var filePaths []string
+	for _, path := range strings.Split(os.Getenv("HELM_MEMORY_DRIVER_DATA"), ":") {
+		// Skip the empty segments left by an unset variable or by "a::b".
+		if path != "" {
+			filePaths = append(filePaths, path)
+		}
+	}
 	if len(filePaths) == 0 {
 		return
 	}
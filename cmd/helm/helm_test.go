@@ -21,14 +21,17 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
 
 	shellwords "github.com/mattn/go-shellwords"
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
 
 	"helm.sh/helm/v3/internal/test"
+	"helm.sh/helm/v3/internal/test/ensure"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/cli"
@@ -238,3 +241,53 @@ func TestPluginExitCode(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadReleasesInMemory(t *testing.T) {
+	dir := ensure.TempDir(t)
+	defer os.RemoveAll(dir)
+	defer testChdir(t, dir)()
+
+	for _, name := range []string{"a", "b"} {
+		rels := []*release.Release{release.Mock(&release.MockReleaseOptions{Name: name})}
+		b, err := yaml.Marshal(rels)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name+".yaml"), b, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		set      bool
+		data     string
+		expected int
+	}{
+		{name: "unset", expected: 0},
+		{name: "empty", set: true, data: "", expected: 0},
+		{name: "empty segment", set: true, data: "a.yaml::b.yaml", expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer resetEnv()()
+
+			os.Unsetenv("HELM_MEMORY_DRIVER_DATA")
+			if tt.set {
+				os.Setenv("HELM_MEMORY_DRIVER_DATA", tt.data)
+			}
+
+			actionConfig := &action.Configuration{Releases: storageFixture()}
+			loadReleasesInMemory(actionConfig)
+
+			rels, err := actionConfig.Releases.ListReleases()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rels) != tt.expected {
+				t.Errorf("expected %d releases, got %d", tt.expected, len(rels))
+			}
+		})
+	}
+}